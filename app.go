@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// App wires together the database, cache, repository, service, and
+// controllers, and exposes the fully configured router.
+type App struct {
+	DB             *gorm.DB
+	Redis          *redis.Client
+	UserRepository UserRepository
+	UserService    *UserService
+	UserController *UserController
+	AuthController *AuthController
+	Router         *gin.Engine
+}
+
+// NewApp constructs the application's dependency graph from an already
+// connected database and Redis client.
+func NewApp(cfg *Config, db *gorm.DB, redisClient *redis.Client, logger *zap.Logger) *App {
+	repo := NewGormUserRepository(db, logger)
+	userService := NewUserService(repo, redisClient, cfg.CacheTTL)
+	userController := NewUserController(userService)
+	authController := NewAuthController(userService, cfg.JWT)
+
+	return &App{
+		DB:             db,
+		Redis:          redisClient,
+		UserRepository: repo,
+		UserService:    userService,
+		UserController: userController,
+		AuthController: authController,
+		Router:         setupRoutes(userService, userController, authController, cfg.JWT, cfg, logger),
+	}
+}
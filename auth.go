@@ -0,0 +1,293 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// errMissingBearerToken is returned when the Authorization header is absent
+// or not a Bearer token.
+var errMissingBearerToken = errors.New("missing bearer token")
+
+const bcryptCost = bcrypt.DefaultCost
+
+// authContextUserKey is the gin context key AuthMiddleware stores the
+// authenticated user under.
+const authContextUserKey = "authUser"
+
+// Token types distinguish access tokens, which AuthMiddleware accepts for
+// authenticating requests, from refresh tokens, which must never be usable
+// as a bearer credential on their own.
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+)
+
+// userClaims are the JWT claims embedded in both access and refresh tokens.
+type userClaims struct {
+	UserID    uint   `json:"user_id"`
+	TokenType string `json:"token_type"`
+	jwt.RegisteredClaims
+}
+
+// RegisterRequest is the payload for POST /auth/register.
+type RegisterRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// LoginRequest is the payload for POST /auth/login.
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// TokenPair is returned on successful registration or login.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// AuthController handles authentication HTTP requests.
+type AuthController struct {
+	service *UserService
+	jwt     JWTConfig
+}
+
+// NewAuthController creates a new AuthController instance.
+func NewAuthController(service *UserService, jwtConfig JWTConfig) *AuthController {
+	return &AuthController{service: service, jwt: jwtConfig}
+}
+
+// Register handles POST /auth/register
+func (c *AuthController) Register(ctx *gin.Context) {
+	var req RegisterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcryptCost)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		return
+	}
+
+	user := User{
+		Name:         req.Name,
+		Email:        req.Email,
+		PasswordHash: string(hash),
+	}
+	if err := c.service.Create(ctx.Request.Context(), &user); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+		return
+	}
+
+	tokens, err := issueTokenPair(c.jwt, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue tokens"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, tokens)
+}
+
+// Login handles POST /auth/login
+func (c *AuthController) Login(ctx *gin.Context) {
+	var req LoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := c.service.GetByEmail(ctx.Request.Context(), req.Email)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		return
+	}
+
+	tokens, err := issueTokenPair(c.jwt, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue tokens"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+// Logout handles POST /auth/logout, denylisting the presented access token
+// in Redis until its natural expiry.
+func (c *AuthController) Logout(ctx *gin.Context) {
+	tokenString, err := bearerToken(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := parseToken(c.jwt, tokenString)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		return
+	}
+
+	remaining := time.Until(claims.ExpiresAt.Time)
+	if remaining > 0 {
+		if err := c.service.redis.Set(ctx, denylistKey(claims.ID), "1", remaining).Err(); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// issueTokenPair signs a fresh access and refresh token for the given user.
+func issueTokenPair(cfg JWTConfig, userID uint) (TokenPair, error) {
+	access, err := signToken(cfg, userID, tokenTypeAccess, cfg.AccessTTL)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	refresh, err := signToken(cfg, userID, tokenTypeRefresh, cfg.RefreshTTL)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return TokenPair{AccessToken: access, RefreshToken: refresh}, nil
+}
+
+// signToken signs a single HS256 JWT of the given type, carrying userID with
+// the given lifetime.
+func signToken(cfg JWTConfig, userID uint, tokenType string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := userClaims{
+		UserID:    userID,
+		TokenType: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.Secret))
+}
+
+// parseToken validates a JWT's signature and expiry and returns its claims.
+func parseToken(cfg JWTConfig, tokenString string) (*userClaims, error) {
+	claims := &userClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(cfg.Secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// bearerToken extracts the raw token from the Authorization header.
+func bearerToken(ctx *gin.Context) (string, error) {
+	header := ctx.GetHeader("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", errMissingBearerToken
+	}
+	return strings.TrimPrefix(header, prefix), nil
+}
+
+// denylistKey is the Redis key a logged-out token's jti is stored under.
+func denylistKey(jti string) string {
+	return "auth:denylist:" + jti
+}
+
+// AuthMiddleware validates the Authorization: Bearer header, rejects
+// denylisted or invalid tokens, and injects the authenticated *User into
+// the gin context.
+func AuthMiddleware(service *UserService, jwtConfig JWTConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenString, err := bearerToken(ctx)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		claims, err := parseToken(jwtConfig, tokenString)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		if claims.TokenType != tokenTypeAccess {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		denylisted, err := service.redis.Exists(ctx, denylistKey(claims.ID)).Result()
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify token"})
+			return
+		}
+		if denylisted > 0 {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			return
+		}
+
+		user, err := service.GetByID(ctx, claims.UserID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+			return
+		}
+
+		ctx.Set(authContextUserKey, user)
+		ctx.Next()
+	}
+}
+
+// RequireOwnerOrAdmin returns a middleware that aborts with 403 unless the
+// authenticated user is an admin or owns the :id route param.
+func RequireOwnerOrAdmin() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, ok := ctx.MustGet(authContextUserKey).(*User)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+			return
+		}
+
+		if user.IsAdmin || ctx.Param("id") == strconv.FormatUint(uint64(user.ID), 10) {
+			ctx.Next()
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Not authorized"})
+	}
+}
+
+// RequireAdmin returns a middleware that aborts with 403 unless the
+// authenticated user is an admin.
+func RequireAdmin() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, ok := ctx.MustGet(authContextUserKey).(*User)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+			return
+		}
+
+		if !user.IsAdmin {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Not authorized"})
+			return
+		}
+
+		ctx.Next()
+	}
+}
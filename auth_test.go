@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func testJWTConfig() JWTConfig {
+	return JWTConfig{
+		Secret:     "test-secret",
+		AccessTTL:  time.Minute,
+		RefreshTTL: time.Hour,
+	}
+}
+
+// newTestAuthService wires a UserService to an in-memory repository and a
+// miniredis-backed cache, so AuthMiddleware's denylist check can be
+// exercised without a real Redis deployment.
+func newTestAuthService(t *testing.T) *UserService {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewInMemoryUserRepository()
+	return NewUserService(repo, redisClient, time.Minute)
+}
+
+func TestPasswordHashRoundTrip(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("hunter2"), bcryptCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword: %v", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(hash, []byte("hunter2")); err != nil {
+		t.Fatalf("expected matching password to compare successfully: %v", err)
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte("wrong")); err == nil {
+		t.Fatalf("expected mismatched password to fail comparison")
+	}
+}
+
+func TestSignAndParseToken(t *testing.T) {
+	cfg := testJWTConfig()
+
+	tokenString, err := signToken(cfg, 42, tokenTypeAccess, cfg.AccessTTL)
+	if err != nil {
+		t.Fatalf("signToken: %v", err)
+	}
+
+	claims, err := parseToken(cfg, tokenString)
+	if err != nil {
+		t.Fatalf("parseToken: %v", err)
+	}
+	if claims.UserID != 42 {
+		t.Fatalf("got UserID %d, want 42", claims.UserID)
+	}
+	if claims.TokenType != tokenTypeAccess {
+		t.Fatalf("got TokenType %q, want %q", claims.TokenType, tokenTypeAccess)
+	}
+}
+
+func TestIssueTokenPairTagsDistinctTokenTypes(t *testing.T) {
+	cfg := testJWTConfig()
+
+	tokens, err := issueTokenPair(cfg, 7)
+	if err != nil {
+		t.Fatalf("issueTokenPair: %v", err)
+	}
+
+	accessClaims, err := parseToken(cfg, tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("parseToken(access): %v", err)
+	}
+	if accessClaims.TokenType != tokenTypeAccess {
+		t.Fatalf("got access TokenType %q, want %q", accessClaims.TokenType, tokenTypeAccess)
+	}
+
+	refreshClaims, err := parseToken(cfg, tokens.RefreshToken)
+	if err != nil {
+		t.Fatalf("parseToken(refresh): %v", err)
+	}
+	if refreshClaims.TokenType != tokenTypeRefresh {
+		t.Fatalf("got refresh TokenType %q, want %q", refreshClaims.TokenType, tokenTypeRefresh)
+	}
+}
+
+func TestAuthMiddlewareRejectsRefreshToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := testJWTConfig()
+	service := newTestAuthService(t)
+
+	user := &User{Name: "Ada", Email: "ada@example.com"}
+	if err := service.Create(context.Background(), user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	router := gin.New()
+	router.GET("/protected", AuthMiddleware(service, cfg), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	tokens, err := issueTokenPair(cfg, user.ID)
+	if err != nil {
+		t.Fatalf("issueTokenPair: %v", err)
+	}
+
+	accessReq := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	accessReq.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	accessRec := httptest.NewRecorder()
+	router.ServeHTTP(accessRec, accessReq)
+	if accessRec.Code != http.StatusOK {
+		t.Fatalf("access token: got status %d, want %d", accessRec.Code, http.StatusOK)
+	}
+
+	refreshReq := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	refreshReq.Header.Set("Authorization", "Bearer "+tokens.RefreshToken)
+	refreshRec := httptest.NewRecorder()
+	router.ServeHTTP(refreshRec, refreshReq)
+	if refreshRec.Code != http.StatusUnauthorized {
+		t.Fatalf("refresh token: got status %d, want %d", refreshRec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthMiddlewareRejectsDenylistedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := testJWTConfig()
+	service := newTestAuthService(t)
+
+	user := &User{Name: "Ada", Email: "ada@example.com"}
+	if err := service.Create(context.Background(), user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	tokenString, err := signToken(cfg, user.ID, tokenTypeAccess, cfg.AccessTTL)
+	if err != nil {
+		t.Fatalf("signToken: %v", err)
+	}
+	claims, err := parseToken(cfg, tokenString)
+	if err != nil {
+		t.Fatalf("parseToken: %v", err)
+	}
+	if err := service.redis.Set(context.Background(), denylistKey(claims.ID), "1", time.Minute).Err(); err != nil {
+		t.Fatalf("denylist Set: %v", err)
+	}
+
+	router := gin.New()
+	router.GET("/protected", AuthMiddleware(service, cfg), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+// withAuthUser injects a *User into the gin context ahead of the middleware
+// under test, standing in for AuthMiddleware in isolation.
+func withAuthUser(user *User) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(authContextUserKey, user)
+		ctx.Next()
+	}
+}
+
+func TestRequireOwnerOrAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	owner := &User{ID: 1, Name: "Ada"}
+	other := &User{ID: 2, Name: "Bob"}
+	admin := &User{ID: 3, Name: "Carol", IsAdmin: true}
+
+	newRouter := func(user *User) *gin.Engine {
+		router := gin.New()
+		router.GET("/users/:id", withAuthUser(user), RequireOwnerOrAdmin(), func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"ok": true})
+		})
+		return router
+	}
+
+	cases := []struct {
+		name string
+		user *User
+		want int
+	}{
+		{"owner", owner, http.StatusOK},
+		{"other", other, http.StatusForbidden},
+		{"admin", admin, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newRouter(tc.user)
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/users/%d", owner.ID), nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("got status %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(user *User) *gin.Engine {
+		router := gin.New()
+		router.GET("/users", withAuthUser(user), RequireAdmin(), func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"ok": true})
+		})
+		return router
+	}
+
+	cases := []struct {
+		name string
+		user *User
+		want int
+	}{
+		{"admin", &User{ID: 1, IsAdmin: true}, http.StatusOK},
+		{"non-admin", &User{ID: 2, IsAdmin: false}, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newRouter(tc.user)
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("got status %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+// TestCreateUserCannotEscalatePrivileges is a regression test: POST /users
+// must never honor a caller-supplied is_admin field.
+func TestCreateUserCannotEscalatePrivileges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := newTestController(t)
+	router := newTestRouter(controller)
+
+	payload := []byte(`{"name":"Mallory","email":"mallory@example.com","is_admin":true}`)
+	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("CreateUser: got status %d, body %s", rec.Code, rec.Body.String())
+	}
+
+	var created User
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if created.IsAdmin {
+		t.Fatalf("CreateUser must not honor a caller-supplied is_admin field")
+	}
+}
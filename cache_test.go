@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// newTestUserService wires a UserService to an in-memory repository and a
+// miniredis instance, so the cache layer can be exercised without a real
+// Postgres or Redis deployment.
+func newTestUserService(t *testing.T) (*UserService, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewInMemoryUserRepository()
+	return NewUserService(repo, redisClient, time.Minute), mr
+}
+
+func TestUserServiceCreatePrimesCache(t *testing.T) {
+	service, mr := newTestUserService(t)
+	ctx := context.Background()
+
+	user := &User{Name: "Ada", Email: "ada@example.com"}
+	if err := service.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if !mr.Exists(userCacheKey(user.ID)) {
+		t.Fatalf("expected Create to prime the user cache entry")
+	}
+}
+
+func TestUserServiceGetByIDServesFromCache(t *testing.T) {
+	service, mr := newTestUserService(t)
+	ctx := context.Background()
+
+	user := &User{Name: "Ada", Email: "ada@example.com"}
+	if err := service.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Overwrite the cached payload directly so a cache hit is distinguishable
+	// from a fallback to the repository.
+	mr.Set(userCacheKey(user.ID), `{"id":1,"name":"Cached Ada","email":"ada@example.com"}`)
+
+	fetched, err := service.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if fetched.Name != "Cached Ada" {
+		t.Fatalf("got name %q, want the cached payload to win", fetched.Name)
+	}
+}
+
+func TestUserServiceUpdateRefreshesCache(t *testing.T) {
+	service, mr := newTestUserService(t)
+	ctx := context.Background()
+
+	user := &User{Name: "Ada", Email: "ada@example.com"}
+	if err := service.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	user.Name = "Ada Lovelace"
+	if err := service.Update(ctx, user); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	raw, err := mr.Get(userCacheKey(user.ID))
+	if err != nil {
+		t.Fatalf("expected a cache entry after Update: %v", err)
+	}
+	if !strings.Contains(raw, "Ada Lovelace") {
+		t.Fatalf("cached payload was not refreshed: %s", raw)
+	}
+}
+
+func TestUserServiceDeleteEvictsCache(t *testing.T) {
+	service, mr := newTestUserService(t)
+	ctx := context.Background()
+
+	user := &User{Name: "Ada", Email: "ada@example.com"}
+	if err := service.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := service.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if mr.Exists(userCacheKey(user.ID)) {
+		t.Fatalf("expected Delete to evict the cache entry")
+	}
+}
+
+func TestUserServiceGetAllCacheBumpsOnMutation(t *testing.T) {
+	service, _ := newTestUserService(t)
+	ctx := context.Background()
+
+	query := UserListQuery{Limit: 10, SortColumn: "id", SortOrder: "asc"}
+	if _, _, err := service.GetAll(ctx, query); err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+
+	before, err := service.listVersion(ctx)
+	if err != nil {
+		t.Fatalf("listVersion: %v", err)
+	}
+
+	if err := service.Create(ctx, &User{Name: "Ada", Email: "ada@example.com"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	after, err := service.listVersion(ctx)
+	if err != nil {
+		t.Fatalf("listVersion: %v", err)
+	}
+
+	if after <= before {
+		t.Fatalf("expected list cache version to advance after a mutation, got %d -> %d", before, after)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DBConfig holds the Postgres connection parameters.
+type DBConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+	Name     string `mapstructure:"name"`
+	SSLMode  string `mapstructure:"sslmode"`
+}
+
+// DSN builds the Postgres connection string gorm expects.
+func (c DBConfig) DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
+		c.Host, c.User, c.Password, c.Name, c.Port, c.SSLMode)
+}
+
+// RedisConfig holds the Redis connection parameters.
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+// JWTConfig holds the settings AuthController and AuthMiddleware sign and
+// validate tokens with.
+type JWTConfig struct {
+	Secret     string        `mapstructure:"secret"`
+	AccessTTL  time.Duration `mapstructure:"access_ttl"`
+	RefreshTTL time.Duration `mapstructure:"refresh_ttl"`
+}
+
+// Config is the fully resolved application configuration, loaded from a
+// config file with environment variable overrides.
+type Config struct {
+	DB           DBConfig      `mapstructure:"db"`
+	Redis        RedisConfig   `mapstructure:"redis"`
+	JWT          JWTConfig     `mapstructure:"jwt"`
+	CacheTTL     time.Duration `mapstructure:"cache_ttl"`
+	HTTPAddr     string        `mapstructure:"http_addr"`
+	LogLevel     string        `mapstructure:"log_level"`
+	PprofEnabled bool          `mapstructure:"pprof_enabled"`
+}
+
+// LoadConfig reads configuration from the given file (TOML, YAML, or JSON,
+// inferred from its extension) and applies APP_-prefixed environment
+// variable overrides, e.g. APP_DB_HOST or APP_JWT_SECRET.
+func LoadConfig(path string) (*Config, error) {
+	v := viper.New()
+
+	v.SetDefault("db.host", "localhost")
+	v.SetDefault("db.port", 5432)
+	v.SetDefault("db.user", "postgres")
+	v.SetDefault("db.password", "postgres")
+	v.SetDefault("db.name", "myapp")
+	v.SetDefault("db.sslmode", "disable")
+	v.SetDefault("redis.addr", "localhost:6379")
+	v.SetDefault("redis.password", "")
+	v.SetDefault("redis.db", 0)
+	v.SetDefault("jwt.secret", "change-me-in-production")
+	v.SetDefault("jwt.access_ttl", 15*time.Minute)
+	v.SetDefault("jwt.refresh_ttl", 7*24*time.Hour)
+	v.SetDefault("cache_ttl", 10*time.Minute)
+	v.SetDefault("http_addr", ":8080")
+	v.SetDefault("log_level", "info")
+	v.SetDefault("pprof_enabled", false)
+
+	v.SetEnvPrefix("APP")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	if path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestController wires a UserController to an in-memory repository and a
+// miniredis-backed cache, so the HTTP layer can be exercised without
+// Postgres or a real Redis deployment.
+func newTestController(t *testing.T) *UserController {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewInMemoryUserRepository()
+	service := NewUserService(repo, redisClient, time.Minute)
+	return NewUserController(service)
+}
+
+func newTestRouter(controller *UserController) *gin.Engine {
+	router := gin.New()
+	router.POST("/users", controller.CreateUser)
+	router.GET("/users/:id", controller.GetUser)
+	router.PUT("/users/:id", controller.UpdateUser)
+	router.DELETE("/users/:id", controller.DeleteUser)
+	return router
+}
+
+func doJSON(router *gin.Engine, method, path string, payload interface{}) *httptest.ResponseRecorder {
+	var body bytes.Reader
+	if payload != nil {
+		b, _ := json.Marshal(payload)
+		body = *bytes.NewReader(b)
+	}
+	req := httptest.NewRequest(method, path, &body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCreateAndGetUser(t *testing.T) {
+	router := newTestRouter(newTestController(t))
+
+	createRec := doJSON(router, http.MethodPost, "/users", User{Name: "Ada", Email: "ada@example.com"})
+	if createRec.Code != http.StatusCreated {
+		t.Fatalf("CreateUser: got status %d, body %s", createRec.Code, createRec.Body.String())
+	}
+
+	var created User
+	if err := json.Unmarshal(createRec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("unmarshal created user: %v", err)
+	}
+
+	getRec := doJSON(router, http.MethodGet, fmt.Sprintf("/users/%d", created.ID), nil)
+	if getRec.Code != http.StatusOK {
+		t.Fatalf("GetUser: got status %d, body %s", getRec.Code, getRec.Body.String())
+	}
+
+	var fetched User
+	if err := json.Unmarshal(getRec.Body.Bytes(), &fetched); err != nil {
+		t.Fatalf("unmarshal fetched user: %v", err)
+	}
+	if fetched.Email != "ada@example.com" {
+		t.Fatalf("got email %q, want %q", fetched.Email, "ada@example.com")
+	}
+}
+
+func TestGetUserNotFound(t *testing.T) {
+	router := newTestRouter(newTestController(t))
+
+	rec := doJSON(router, http.MethodGet, "/users/999", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestUpdateUserOnlyChangesNameAndEmail(t *testing.T) {
+	router := newTestRouter(newTestController(t))
+
+	createRec := doJSON(router, http.MethodPost, "/users", User{Name: "Ada", Email: "ada@example.com", IsAdmin: false})
+	var created User
+	json.Unmarshal(createRec.Body.Bytes(), &created)
+
+	updateRec := doJSON(router, http.MethodPut, fmt.Sprintf("/users/%d", created.ID),
+		UpdateUserRequest{Name: "Ada Lovelace", Email: "ada@example.com"})
+	if updateRec.Code != http.StatusOK {
+		t.Fatalf("UpdateUser: got status %d, body %s", updateRec.Code, updateRec.Body.String())
+	}
+
+	var updated User
+	json.Unmarshal(updateRec.Body.Bytes(), &updated)
+	if updated.Name != "Ada Lovelace" {
+		t.Fatalf("got name %q, want %q", updated.Name, "Ada Lovelace")
+	}
+	if updated.IsAdmin {
+		t.Fatalf("UpdateUser must not be able to grant admin privileges")
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	router := newTestRouter(newTestController(t))
+
+	createRec := doJSON(router, http.MethodPost, "/users", User{Name: "Ada", Email: "ada@example.com"})
+	var created User
+	json.Unmarshal(createRec.Body.Bytes(), &created)
+
+	deleteRec := doJSON(router, http.MethodDelete, fmt.Sprintf("/users/%d", created.ID), nil)
+	if deleteRec.Code != http.StatusOK {
+		t.Fatalf("DeleteUser: got status %d, body %s", deleteRec.Code, deleteRec.Body.String())
+	}
+
+	getRec := doJSON(router, http.MethodGet, fmt.Sprintf("/users/%d", created.ID), nil)
+	if getRec.Code != http.StatusNotFound {
+		t.Fatalf("expected deleted user to 404, got %d", getRec.Code)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	ginpprof "github.com/gin-contrib/pprof"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// requestIDHeader is the header clients can supply (or will receive) a
+// per-request correlation ID on.
+const requestIDHeader = "X-Request-ID"
+
+// requestIDContextKey is the context.Context key RequestIDMiddleware stores
+// the request ID under so it can be threaded down into the service and
+// GORM layers for log correlation.
+type requestIDContextKey struct{}
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests processed, labeled by method, path, and status.",
+	}, []string{"method", "path", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency in seconds, labeled by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequestsTotal, httpRequestDuration, dbQueryDuration)
+}
+
+// observeDBQuery records the elapsed time since start under the given
+// operation label, and logs failures with the request ID carried on ctx so
+// GORM/service-layer errors can be correlated back to the request that
+// caused them.
+func observeDBQuery(ctx context.Context, logger *zap.Logger, operation string, start time.Time, err error) {
+	dbQueryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+
+	if err != nil && logger != nil {
+		logger.Error("db_query_failed",
+			zap.String("request_id", requestIDFromContext(ctx)),
+			zap.String("operation", operation),
+			zap.Error(err),
+		)
+	}
+}
+
+// NewLogger builds a zap logger at the level configured in cfg.LogLevel.
+func NewLogger(cfg *Config) (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	zapConfig := zap.NewProductionConfig()
+	zapConfig.Level = zap.NewAtomicLevelAt(level)
+	return zapConfig.Build()
+}
+
+// RequestIDMiddleware assigns a UUID to every request that doesn't already
+// carry one, echoes it back via X-Request-ID, and stashes it on the request
+// context so downstream logging can correlate a request end to end.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		ctx.Header(requestIDHeader, requestID)
+		ctx.Request = ctx.Request.WithContext(
+			context.WithValue(ctx.Request.Context(), requestIDContextKey{}, requestID),
+		)
+		ctx.Next()
+	}
+}
+
+// requestIDFromContext returns the request ID stashed by RequestIDMiddleware,
+// or "" if ctx didn't come from an instrumented request.
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// ZapLoggerMiddleware replaces gin's default logger with structured,
+// per-request zap logging and records Prometheus request metrics.
+func ZapLoggerMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		path := ctx.FullPath()
+
+		ctx.Next()
+
+		duration := time.Since(start)
+		status := ctx.Writer.Status()
+
+		httpRequestsTotal.WithLabelValues(ctx.Request.Method, path, strconv.Itoa(status)).Inc()
+		httpRequestDuration.WithLabelValues(ctx.Request.Method, path).Observe(duration.Seconds())
+
+		logger.Info("http_request",
+			zap.String("request_id", requestIDFromContext(ctx.Request.Context())),
+			zap.String("method", ctx.Request.Method),
+			zap.String("path", path),
+			zap.Int("status", status),
+			zap.Duration("duration", duration),
+		)
+	}
+}
+
+// registerObservabilityRoutes exposes /metrics and, when cfg.PprofEnabled,
+// /debug/pprof.
+func registerObservabilityRoutes(router *gin.Engine, cfg *Config) {
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	if cfg.PprofEnabled {
+		ginpprof.Register(router)
+	}
+}
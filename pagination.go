@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userSortableColumns whitelists the columns GetAllUsers may sort by, so a
+// client-supplied sort_column can never be interpolated into SQL directly.
+var userSortableColumns = map[string]bool{
+	"id":         true,
+	"name":       true,
+	"email":      true,
+	"created_at": true,
+}
+
+const (
+	defaultUserListLimit = 20
+	maxUserListLimit     = 100
+)
+
+// UserListQuery carries the pagination, sorting, and filtering parameters
+// for UserService.GetAll.
+type UserListQuery struct {
+	Limit       int
+	Offset      int
+	SortColumn  string
+	SortOrder   string
+	FilterName  string
+	FilterEmail string
+}
+
+// cacheKey returns a stable string encoding of the query, used to key the
+// per-query "all users" cache entry.
+func (q UserListQuery) cacheKey() string {
+	return fmt.Sprintf("limit=%d&offset=%d&sort=%s+%s&name=%s&email=%s",
+		q.Limit, q.Offset, q.SortColumn, q.SortOrder, q.FilterName, q.FilterEmail)
+}
+
+// userListEnvelope is the paginated response body for GET /users.
+type userListEnvelope struct {
+	Data   []User `json:"data"`
+	Total  int64  `json:"total"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// parseUserListQuery reads and validates pagination, sorting, and filter
+// query params from the request, applying repo defaults where absent.
+func parseUserListQuery(ctx *gin.Context) (UserListQuery, error) {
+	query := UserListQuery{
+		Limit:      defaultUserListLimit,
+		SortColumn: "id",
+		SortOrder:  "asc",
+	}
+
+	if raw := ctx.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return query, fmt.Errorf("limit must be a positive integer")
+		}
+		if limit > maxUserListLimit {
+			limit = maxUserListLimit
+		}
+		query.Limit = limit
+	}
+
+	if raw := ctx.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return query, fmt.Errorf("offset must be a non-negative integer")
+		}
+		query.Offset = offset
+	}
+
+	if raw := ctx.Query("sort_column"); raw != "" {
+		if !userSortableColumns[raw] {
+			return query, fmt.Errorf("sort_column must be one of: id, name, email, created_at")
+		}
+		query.SortColumn = raw
+	}
+
+	if raw := ctx.Query("sort_order"); raw != "" {
+		if raw != "asc" && raw != "desc" {
+			return query, fmt.Errorf("sort_order must be asc or desc")
+		}
+		query.SortOrder = raw
+	}
+
+	query.FilterName = ctx.Query("filter[name]")
+	query.FilterEmail = ctx.Query("filter[email]")
+
+	return query, nil
+}
+
+// GetAllUsers handles GET /users
+func (c *UserController) GetAllUsers(ctx *gin.Context) {
+	query, err := parseUserListQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	users, total, err := c.service.GetAll(ctx.Request.Context(), query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve users"})
+		return
+	}
+
+	ctx.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	ctx.JSON(http.StatusOK, userListEnvelope{
+		Data:   users,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// gormUserRepository is the Postgres-backed UserRepository implementation.
+type gormUserRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewGormUserRepository creates a UserRepository backed by the given gorm.DB.
+// logger is used to correlate query failures back to the request ID carried
+// on ctx.
+func NewGormUserRepository(db *gorm.DB, logger *zap.Logger) UserRepository {
+	return &gormUserRepository{db: db, logger: logger}
+}
+
+// Create creates a new user
+func (r *gormUserRepository) Create(ctx context.Context, user *User) (err error) {
+	start := time.Now()
+	defer func() { observeDBQuery(ctx, r.logger, "create", start, err) }()
+	err = r.db.WithContext(ctx).Create(user).Error
+	return err
+}
+
+// GetByID retrieves a user by ID
+func (r *gormUserRepository) GetByID(ctx context.Context, id uint) (user *User, err error) {
+	start := time.Now()
+	defer func() { observeDBQuery(ctx, r.logger, "get_by_id", start, err) }()
+	user = &User{}
+	err = r.db.WithContext(ctx).First(user, id).Error
+	return user, err
+}
+
+// GetByEmail retrieves a user by email
+func (r *gormUserRepository) GetByEmail(ctx context.Context, email string) (user *User, err error) {
+	start := time.Now()
+	defer func() { observeDBQuery(ctx, r.logger, "get_by_email", start, err) }()
+	user = &User{}
+	err = r.db.WithContext(ctx).Where("email = ?", email).First(user).Error
+	return user, err
+}
+
+// GetAll retrieves a page of users matching query, along with the total
+// count of matching rows ignoring pagination.
+func (r *gormUserRepository) GetAll(ctx context.Context, query UserListQuery) (users []User, total int64, err error) {
+	start := time.Now()
+	defer func() { observeDBQuery(ctx, r.logger, "get_all", start, err) }()
+
+	scope := r.db.WithContext(ctx).Model(&User{})
+	if query.FilterName != "" {
+		scope = scope.Where("name LIKE ?", "%"+query.FilterName+"%")
+	}
+	if query.FilterEmail != "" {
+		scope = scope.Where("email LIKE ?", "%"+query.FilterEmail+"%")
+	}
+
+	if err = scope.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err = scope.
+		Order(query.SortColumn + " " + query.SortOrder).
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&users).Error
+	return users, total, err
+}
+
+// updatableUserColumns whitelists the columns Update is allowed to write, so
+// a struct built from a partial field merge can never zero out columns it
+// didn't intend to touch (e.g. PasswordHash).
+var updatableUserColumns = []string{"Name", "Email", "IsAdmin"}
+
+// Update updates a user's whitelisted columns. It uses Select+Updates
+// instead of Save so columns outside updatableUserColumns are never
+// overwritten, regardless of what the caller's *User struct holds.
+func (r *gormUserRepository) Update(ctx context.Context, user *User) (err error) {
+	start := time.Now()
+	defer func() { observeDBQuery(ctx, r.logger, "update", start, err) }()
+	err = r.db.WithContext(ctx).Model(&User{}).Where("id = ?", user.ID).
+		Select(updatableUserColumns).
+		Updates(user).Error
+	return err
+}
+
+// Delete deletes a user by ID
+func (r *gormUserRepository) Delete(ctx context.Context, id uint) (err error) {
+	start := time.Now()
+	defer func() { observeDBQuery(ctx, r.logger, "delete", start, err) }()
+	err = r.db.WithContext(ctx).Delete(&User{}, id).Error
+	return err
+}
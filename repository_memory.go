@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// inMemoryUserRepository is a UserRepository implementation backed by a map,
+// used to exercise the HTTP layer in tests without a real Postgres instance.
+type inMemoryUserRepository struct {
+	mu     sync.Mutex
+	users  map[uint]User
+	nextID uint
+}
+
+// NewInMemoryUserRepository creates an empty in-memory UserRepository.
+func NewInMemoryUserRepository() UserRepository {
+	return &inMemoryUserRepository{
+		users:  make(map[uint]User),
+		nextID: 1,
+	}
+}
+
+// Create creates a new user
+func (r *inMemoryUserRepository) Create(ctx context.Context, user *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user.ID = r.nextID
+	r.nextID++
+	r.users[user.ID] = *user
+	return nil
+}
+
+// GetByID retrieves a user by ID
+func (r *inMemoryUserRepository) GetByID(ctx context.Context, id uint) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return &User{}, fmt.Errorf("user %d not found", id)
+	}
+	return &user, nil
+}
+
+// GetByEmail retrieves a user by email
+func (r *inMemoryUserRepository) GetByEmail(ctx context.Context, email string) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return &User{}, fmt.Errorf("user with email %q not found", email)
+}
+
+// GetAll retrieves a page of users matching query, along with the total
+// count of matching rows ignoring pagination.
+func (r *inMemoryUserRepository) GetAll(ctx context.Context, query UserListQuery) ([]User, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched := make([]User, 0, len(r.users))
+	for _, user := range r.users {
+		if query.FilterName != "" && !strings.Contains(user.Name, query.FilterName) {
+			continue
+		}
+		if query.FilterEmail != "" && !strings.Contains(user.Email, query.FilterEmail) {
+			continue
+		}
+		matched = append(matched, user)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		less := userFieldLess(matched[i], matched[j], query.SortColumn)
+		if query.SortOrder == "desc" {
+			return !less
+		}
+		return less
+	})
+
+	total := int64(len(matched))
+
+	start := query.Offset
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + query.Limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return matched[start:end], total, nil
+}
+
+// userFieldLess compares two users by the given whitelisted column.
+func userFieldLess(a, b User, column string) bool {
+	switch column {
+	case "name":
+		return a.Name < b.Name
+	case "email":
+		return a.Email < b.Email
+	case "created_at":
+		return a.CreatedAt.Before(b.CreatedAt)
+	default:
+		return a.ID < b.ID
+	}
+}
+
+// Update updates a user's whitelisted columns (name, email, admin flag),
+// mirroring gormUserRepository's Select+Updates so the two
+// backing stores behave identically instead of the map variant silently
+// overwriting unrelated fields.
+func (r *inMemoryUserRepository) Update(ctx context.Context, user *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.users[user.ID]
+	if !ok {
+		return fmt.Errorf("user %d not found", user.ID)
+	}
+
+	existing.Name = user.Name
+	existing.Email = user.Email
+	existing.IsAdmin = user.IsAdmin
+	r.users[user.ID] = existing
+	return nil
+}
+
+// Delete deletes a user by ID
+func (r *inMemoryUserRepository) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return fmt.Errorf("user %d not found", id)
+	}
+	delete(r.users, id)
+	return nil
+}
@@ -1,31 +1,42 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
-	"gorm.io/gorm"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
+// usersListVersionKey tracks the current generation of the "all users" cache.
+// It is bumped on every mutation so GetAll never serves stale data.
+const usersListVersionKey = "users:list:version"
+
 // User represents a user in the system
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name" gorm:"not null"`
-	Email     string    `json:"email" gorm:"unique;not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Name         string    `json:"name" gorm:"not null"`
+	Email        string    `json:"email" gorm:"unique;not null"`
+	PasswordHash string    `json:"-" gorm:"not null"`
+	IsAdmin      bool      `json:"is_admin" gorm:"not null;default:false"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
 // UserService handles business logic for users
 type UserService struct {
-	db    *gorm.DB
-	redis *redis.Client
+	repo     UserRepository
+	redis    *redis.Client
+	cacheTTL time.Duration
 }
 
 // UserController handles HTTP requests for users
@@ -35,18 +46,69 @@ type UserController struct {
 
 // UserRepository interface defines data access methods
 type UserRepository interface {
-	Create(user *User) error
-	GetByID(id uint) (*User, error)
-	GetAll() ([]User, error)
-	Update(user *User) error
-	Delete(id uint) error
+	Create(ctx context.Context, user *User) error
+	GetByID(ctx context.Context, id uint) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetAll(ctx context.Context, query UserListQuery) ([]User, int64, error)
+	Update(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id uint) error
 }
 
 // NewUserService creates a new UserService instance
-func NewUserService(db *gorm.DB, redis *redis.Client) *UserService {
+func NewUserService(repo UserRepository, redis *redis.Client, cacheTTL time.Duration) *UserService {
 	return &UserService{
-		db:    db,
-		redis: redis,
+		repo:     repo,
+		redis:    redis,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// userCacheKey returns the Redis key holding the cached payload for a user.
+func userCacheKey(id uint) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+// usersListCacheKey returns the Redis key for the current generation of the
+// "all users" list cache.
+func usersListCacheKey(version int64, query UserListQuery) string {
+	return fmt.Sprintf("users:all:v%d:%s", version, query.cacheKey())
+}
+
+// listVersion reads the current "all users" cache generation, treating a
+// missing key as generation 0.
+func (s *UserService) listVersion(ctx context.Context) (int64, error) {
+	version, err := s.redis.Get(ctx, usersListVersionKey).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return version, err
+}
+
+// bumpListVersion invalidates the cached "all users" list by advancing its
+// generation counter.
+func (s *UserService) bumpListVersion(ctx context.Context) {
+	if err := s.redis.Incr(ctx, usersListVersionKey).Err(); err != nil {
+		log.Println("failed to bump users list cache version:", err)
+	}
+}
+
+// cacheUser writes the user payload to Redis under its TTL, logging rather
+// than failing the request if Redis is unavailable.
+func (s *UserService) cacheUser(ctx context.Context, user *User) {
+	payload, err := json.Marshal(user)
+	if err != nil {
+		log.Println("failed to marshal user for cache:", err)
+		return
+	}
+	if err := s.redis.Set(ctx, userCacheKey(user.ID), payload, s.cacheTTL).Err(); err != nil {
+		log.Println("failed to cache user:", err)
+	}
+}
+
+// evictUser removes a user's cached payload.
+func (s *UserService) evictUser(ctx context.Context, id uint) {
+	if err := s.redis.Del(ctx, userCacheKey(id)).Err(); err != nil {
+		log.Println("failed to evict cached user:", err)
 	}
 }
 
@@ -57,44 +119,122 @@ func NewUserController(service *UserService) *UserController {
 	}
 }
 
-// Create creates a new user
-func (s *UserService) Create(user *User) error {
-	return s.db.Create(user).Error
+// Create creates a new user and primes the read-through cache.
+func (s *UserService) Create(ctx context.Context, user *User) error {
+	if err := s.repo.Create(ctx, user); err != nil {
+		return err
+	}
+	s.cacheUser(ctx, user)
+	s.bumpListVersion(ctx)
+	return nil
 }
 
-// GetByID retrieves a user by ID
-func (s *UserService) GetByID(id uint) (*User, error) {
-	var user User
-	err := s.db.First(&user, id).Error
-	return &user, err
+// GetByID retrieves a user by ID, checking Redis before falling back to
+// Postgres and repopulating the cache on a miss.
+func (s *UserService) GetByID(ctx context.Context, id uint) (*User, error) {
+	cached, err := s.redis.Get(ctx, userCacheKey(id)).Bytes()
+	if err == nil {
+		var user User
+		if err := json.Unmarshal(cached, &user); err == nil {
+			return &user, nil
+		}
+	} else if err != redis.Nil {
+		log.Println("failed to read user cache:", err)
+	}
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return user, err
+	}
+	s.cacheUser(ctx, user)
+	return user, nil
+}
+
+// GetByEmail retrieves a user by email, used during login. It bypasses the
+// ID-keyed cache since lookups here are by a different field.
+func (s *UserService) GetByEmail(ctx context.Context, email string) (*User, error) {
+	return s.repo.GetByEmail(ctx, email)
+}
+
+// userListCacheEntry is the JSON payload stored under a versioned "all
+// users" cache key.
+type userListCacheEntry struct {
+	Users []User `json:"users"`
+	Total int64  `json:"total"`
+}
+
+// GetAll retrieves a page of users matching query, serving from a versioned
+// Redis cache that is bumped on every mutation.
+func (s *UserService) GetAll(ctx context.Context, query UserListQuery) ([]User, int64, error) {
+	version, err := s.listVersion(ctx)
+	if err != nil {
+		log.Println("failed to read users list cache version:", err)
+	}
+	key := usersListCacheKey(version, query)
+
+	cached, err := s.redis.Get(ctx, key).Bytes()
+	if err == nil {
+		var entry userListCacheEntry
+		if err := json.Unmarshal(cached, &entry); err == nil {
+			return entry.Users, entry.Total, nil
+		}
+	} else if err != redis.Nil {
+		log.Println("failed to read users list cache:", err)
+	}
+
+	users, total, err := s.repo.GetAll(ctx, query)
+	if err != nil {
+		return users, total, err
+	}
+
+	entry := userListCacheEntry{Users: users, Total: total}
+	if payload, err := json.Marshal(entry); err == nil {
+		if err := s.redis.Set(ctx, key, payload, s.cacheTTL).Err(); err != nil {
+			log.Println("failed to cache users list:", err)
+		}
+	}
+
+	return users, total, nil
 }
 
-// GetAll retrieves all users
-func (s *UserService) GetAll() ([]User, error) {
-	var users []User
-	err := s.db.Find(&users).Error
-	return users, err
+// Update updates a user and refreshes the cache.
+func (s *UserService) Update(ctx context.Context, user *User) error {
+	if err := s.repo.Update(ctx, user); err != nil {
+		return err
+	}
+	s.cacheUser(ctx, user)
+	s.bumpListVersion(ctx)
+	return nil
 }
 
-// Update updates a user
-func (s *UserService) Update(user *User) error {
-	return s.db.Save(user).Error
+// Delete deletes a user by ID and invalidates its cache entry.
+func (s *UserService) Delete(ctx context.Context, id uint) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.evictUser(ctx, id)
+	s.bumpListVersion(ctx)
+	return nil
 }
 
-// Delete deletes a user by ID
-func (s *UserService) Delete(id uint) error {
-	return s.db.Delete(&User{}, id).Error
+// CreateUserRequest is the payload for POST /users. It deliberately omits
+// IsAdmin and PasswordHash so a caller can never use this endpoint to mint
+// an admin account or set a credential directly.
+type CreateUserRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required"`
 }
 
 // CreateUser handles POST /users
 func (c *UserController) CreateUser(ctx *gin.Context) {
-	var user User
-	if err := ctx.ShouldBindJSON(&user); err != nil {
+	var req CreateUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := c.service.Create(&user); err != nil {
+	user := User{Name: req.Name, Email: req.Email}
+	if err := c.service.Create(ctx.Request.Context(), &user); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
 		return
 	}
@@ -110,7 +250,7 @@ func (c *UserController) GetUser(ctx *gin.Context) {
 		return
 	}
 
-	user, err := c.service.GetByID(id)
+	user, err := c.service.GetByID(ctx.Request.Context(), id)
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
@@ -119,33 +259,73 @@ func (c *UserController) GetUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, user)
 }
 
-// GetAllUsers handles GET /users
-func (c *UserController) GetAllUsers(ctx *gin.Context) {
-	users, err := c.service.GetAll()
+// UpdateUserRequest is the self-service payload for PUT /users/:id. It
+// deliberately omits IsAdmin and PasswordHash so a caller can never use this
+// endpoint to escalate privileges or clobber their credentials.
+type UpdateUserRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required"`
+}
+
+// UpdateUser handles PUT /users/:id, updating only the caller's name and
+// email.
+func (c *UserController) UpdateUser(ctx *gin.Context) {
+	var id uint
+	if err := json.Unmarshal([]byte(ctx.Param("id")), &id); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req UpdateUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := c.service.GetByID(ctx.Request.Context(), id)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve users"})
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	user.Name = req.Name
+	user.Email = req.Email
+	if err := c.service.Update(ctx.Request.Context(), user); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, users)
+	ctx.JSON(http.StatusOK, user)
 }
 
-// UpdateUser handles PUT /users/:id
-func (c *UserController) UpdateUser(ctx *gin.Context) {
+// UpdateUserRoleRequest is the admin-only payload for PATCH /users/:id/role.
+type UpdateUserRoleRequest struct {
+	IsAdmin bool `json:"is_admin"`
+}
+
+// UpdateUserRole handles PATCH /users/:id/role, the only way to change a
+// user's admin status. The route must be gated behind RequireAdmin.
+func (c *UserController) UpdateUserRole(ctx *gin.Context) {
 	var id uint
 	if err := json.Unmarshal([]byte(ctx.Param("id")), &id); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
 
-	var user User
-	if err := ctx.ShouldBindJSON(&user); err != nil {
+	var req UpdateUserRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	user.ID = id
-	if err := c.service.Update(&user); err != nil {
+	user, err := c.service.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	user.IsAdmin = req.IsAdmin
+	if err := c.service.Update(ctx.Request.Context(), user); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
 		return
 	}
@@ -161,7 +341,7 @@ func (c *UserController) DeleteUser(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.service.Delete(id); err != nil {
+	if err := c.service.Delete(ctx.Request.Context(), id); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete user"})
 		return
 	}
@@ -170,62 +350,100 @@ func (c *UserController) DeleteUser(ctx *gin.Context) {
 }
 
 // setupRoutes configures the HTTP routes
-func setupRoutes(controller *UserController) *gin.Engine {
-	router := gin.Default()
+func setupRoutes(userService *UserService, controller *UserController, authController *AuthController, jwtConfig JWTConfig, cfg *Config, logger *zap.Logger) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery(), RequestIDMiddleware(), ZapLoggerMiddleware(logger))
+
+	registerObservabilityRoutes(router, cfg)
+
+	auth := router.Group("/auth")
+	{
+		auth.POST("/register", authController.Register)
+		auth.POST("/login", authController.Login)
+		auth.POST("/logout", authController.Logout)
+	}
 
 	api := router.Group("/api/v1")
+	api.Use(AuthMiddleware(userService, jwtConfig))
 	{
 		api.POST("/users", controller.CreateUser)
-		api.GET("/users/:id", controller.GetUser)
-		api.GET("/users", controller.GetAllUsers)
-		api.PUT("/users/:id", controller.UpdateUser)
-		api.DELETE("/users/:id", controller.DeleteUser)
+		api.GET("/users/:id", RequireOwnerOrAdmin(), controller.GetUser)
+		api.GET("/users", RequireAdmin(), controller.GetAllUsers)
+		api.PUT("/users/:id", RequireOwnerOrAdmin(), controller.UpdateUser)
+		api.PATCH("/users/:id/role", RequireAdmin(), controller.UpdateUserRole)
+		api.DELETE("/users/:id", RequireOwnerOrAdmin(), controller.DeleteUser)
 	}
 
 	return router
 }
 
 // initDatabase initializes the database connection
-func initDatabase() (*gorm.DB, error) {
-	dsn := "host=localhost user=postgres password=postgres dbname=myapp port=5432 sslmode=disable"
-	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func initDatabase(cfg *Config) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(cfg.DB.DSN()), &gorm.Config{})
 }
 
 // initRedis initializes the Redis connection
-func initRedis() *redis.Client {
+func initRedis(cfg *Config) *redis.Client {
 	return redis.NewClient(&redis.Options{
-		Addr:     "localhost:6379",
-		Password: "",
-		DB:       0,
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
 	})
 }
 
-// main is the application entry point
-func main() {
+// run loads configuration and starts the HTTP server.
+func run(cliCtx *cli.Context) error {
+	cfg, err := LoadConfig(cliCtx.String("config"))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
 	// Initialize database
-	db, err := initDatabase()
+	db, err := initDatabase(cfg)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
 	// Auto-migrate the schema
 	if err := db.AutoMigrate(&User{}); err != nil {
-		log.Fatal("Failed to migrate database:", err)
+		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
 	// Initialize Redis
-	redisClient := initRedis()
+	redisClient := initRedis(cfg)
 
-	// Initialize services and controllers
-	userService := NewUserService(db, redisClient)
-	userController := NewUserController(userService)
+	// Initialize structured logging
+	logger, err := NewLogger(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to build logger: %w", err)
+	}
+	defer logger.Sync()
 
-	// Setup routes
-	router := setupRoutes(userController)
+	// Wire up the application's dependency graph
+	app := NewApp(cfg, db, redisClient, logger)
 
 	// Start server
-	fmt.Println("Server starting on :8080")
-	if err := router.Run(":8080"); err != nil {
-		log.Fatal("Failed to start server:", err)
+	logger.Info("server starting", zap.String("addr", cfg.HTTPAddr))
+	return app.Router.Run(cfg.HTTPAddr)
+}
+
+// main is the application entry point
+func main() {
+	cliApp := &cli.App{
+		Name:  "mind-map-server",
+		Usage: "starts the user service HTTP API",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Usage:   "path to a config file (TOML/YAML/JSON)",
+				EnvVars: []string{"APP_CONFIG"},
+			},
+		},
+		Action: run,
 	}
-}
\ No newline at end of file
+
+	if err := cliApp.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}